@@ -0,0 +1,129 @@
+package template
+
+import (
+  "encoding/json"
+  "io/ioutil"
+  "os"
+  "strings"
+  "unicode"
+
+  yaml "gopkg.in/yaml.v2"
+)
+
+// AnswerSource supplies pre-computed prompt answers so BindPrompts can
+// skip interactive prompting for variables it resolves. A dirTemplate's
+// AnswerSources are consulted in order; the first one with an answer
+// for a variable wins, and anything left unanswered falls back to the
+// existing interactive prompt / UseDefaultValues behavior.
+type AnswerSource interface {
+  Lookup(name string) (interface{}, bool)
+}
+
+// mapAnswerSource answers from an already-decoded object, shared by the
+// JSON and YAML file sources.
+type mapAnswerSource map[string]interface{}
+
+func (m mapAnswerSource) Lookup(name string) (interface{}, bool) {
+  v, ok := m[name]
+
+  return v, ok
+}
+
+// JSONFileAnswerSource reads answers from a JSON object file, the same
+// shape as project.json.
+func JSONFileAnswerSource(path string) (AnswerSource, error) {
+  b, err := ioutil.ReadFile(path)
+  if err != nil {
+    return nil, err
+  }
+
+  var m map[string]interface{}
+  if err := json.Unmarshal(b, &m); err != nil {
+    return nil, err
+  }
+
+  return mapAnswerSource(m), nil
+}
+
+// YAMLFileAnswerSource reads answers from a YAML object file.
+func YAMLFileAnswerSource(path string) (AnswerSource, error) {
+  b, err := ioutil.ReadFile(path)
+  if err != nil {
+    return nil, err
+  }
+
+  var m map[string]interface{}
+  if err := yaml.Unmarshal(b, &m); err != nil {
+    return nil, err
+  }
+
+  return mapAnswerSource(m), nil
+}
+
+// StdinJSONAnswerSource reads a single JSON object piped on stdin and
+// answers from it.
+func StdinJSONAnswerSource() (AnswerSource, error) {
+  b, err := ioutil.ReadAll(os.Stdin)
+  if err != nil {
+    return nil, err
+  }
+
+  var m map[string]interface{}
+  if err := json.Unmarshal(b, &m); err != nil {
+    return nil, err
+  }
+
+  return mapAnswerSource(m), nil
+}
+
+// EnvAnswerSource answers from BOILR_VAR_<NAME> environment variables,
+// e.g. BOILR_VAR_PROJECT_NAME answers the "ProjectName" variable.
+type EnvAnswerSource struct{}
+
+func (EnvAnswerSource) Lookup(name string) (interface{}, bool) {
+  return os.LookupEnv("BOILR_VAR_" + screamingSnakeCase(name))
+}
+
+// screamingSnakeCase converts a camelCase/PascalCase variable name
+// (e.g. "ProjectName") to its SCREAMING_SNAKE_CASE form ("PROJECT_NAME"),
+// matching the BOILR_VAR_<NAME> convention.
+func screamingSnakeCase(name string) string {
+  var b strings.Builder
+
+  for i, r := range name {
+    if i > 0 && unicode.IsUpper(r) {
+      b.WriteByte('_')
+    }
+
+    b.WriteRune(unicode.ToUpper(r))
+  }
+
+  return b.String()
+}
+
+// GetWithAnswers retrieves the template like Get, additionally
+// registering answer sources that BindPrompts consults before falling
+// back to interactive prompts. Sources are tried in the given order.
+func GetWithAnswers(path string, sources ...AnswerSource) (Interface, error) {
+  t, err := GetEx(path, "")
+  if err != nil {
+    return nil, err
+  }
+
+  dt := t.(*dirTemplate)
+  dt.AnswerSources = sources
+
+  return dt, nil
+}
+
+// lookupAnswer consults AnswerSources in order, returning the first
+// answer found for name.
+func (t *dirTemplate) lookupAnswer(name string) (interface{}, bool) {
+  for _, src := range t.AnswerSources {
+    if val, ok := src.Lookup(name); ok {
+      return val, true
+    }
+  }
+
+  return nil, false
+}
@@ -0,0 +1,17 @@
+package template
+
+import "testing"
+
+func TestScreamingSnakeCase(t *testing.T) {
+  cases := map[string]string{
+    "ProjectName": "PROJECT_NAME",
+    "name":        "NAME",
+    "ID":          "I_D",
+  }
+
+  for in, want := range cases {
+    if got := screamingSnakeCase(in); got != want {
+      t.Errorf("screamingSnakeCase(%q) = %q, want %q", in, got, want)
+    }
+  }
+}
@@ -0,0 +1,69 @@
+package template
+
+import (
+  "io"
+  "net/http"
+  "os"
+  "path/filepath"
+  "strings"
+)
+
+// isBinary reports whether filename should be copied byte-for-byte
+// instead of rendered through the template engine: either its
+// extension/path matches the template's BinaryExtensions/BinaryGlobs,
+// or its content sniffs as non-text.
+func (t *dirTemplate) isBinary(filename, oldName string) (bool, error) {
+  ext := filepath.Ext(oldName)
+  for _, e := range t.Metadata.BinaryExtensions {
+    if strings.EqualFold(e, ext) {
+      return true, nil
+    }
+  }
+
+  if matchAny(t.Metadata.BinaryGlobs, oldName) {
+    return true, nil
+  }
+
+  f, err := os.Open(filename)
+  if err != nil {
+    return false, err
+  }
+  defer f.Close()
+
+  head := make([]byte, 512)
+  n, err := f.Read(head)
+  if err != nil && err != io.EOF {
+    return false, err
+  }
+
+  return !strings.HasPrefix(http.DetectContentType(head[:n]), "text/"), nil
+}
+
+// copyBinary copies filename to target byte-for-byte, preserving the
+// source file's mode.
+func copyBinary(filename, target string) error {
+  src, err := os.Open(filename)
+  if err != nil {
+    return err
+  }
+  defer src.Close()
+
+  fi, err := src.Stat()
+  if err != nil {
+    return err
+  }
+
+  if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+    return err
+  }
+
+  dst, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, fi.Mode())
+  if err != nil {
+    return err
+  }
+  defer dst.Close()
+
+  _, err = io.Copy(dst, src)
+
+  return err
+}
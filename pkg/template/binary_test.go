@@ -0,0 +1,63 @@
+package template
+
+import (
+  "io/ioutil"
+  "os"
+  "path/filepath"
+  "testing"
+)
+
+func TestIsBinary(t *testing.T) {
+  dir, err := ioutil.TempDir("", "boilr-binary")
+  if err != nil {
+    t.Fatal(err)
+  }
+  defer os.RemoveAll(dir)
+
+  write := func(rel string, contents []byte) string {
+    path := filepath.Join(dir, rel)
+    if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+      t.Fatal(err)
+    }
+    if err := ioutil.WriteFile(path, contents, 0644); err != nil {
+      t.Fatal(err)
+    }
+
+    return path
+  }
+
+  textFile := write("readme.txt", []byte("just some plain text\n"))
+  pngFile := write("logo.png", []byte{0x89, 'P', 'N', 'G', 0x0d, 0x0a, 0x1a, 0x0a})
+  extFile := write("notes.custom", []byte("still text\n"))
+  globFile := write("assets/font.dat", []byte("still text\n"))
+
+  tmpl := &dirTemplate{
+    Metadata: Metadata{
+      BinaryExtensions: []string{".custom"},
+      BinaryGlobs:      []string{"assets/*.dat"},
+    },
+  }
+
+  cases := []struct {
+    name     string
+    filename string
+    oldName  string
+    want     bool
+  }{
+    {"plain text sniffed as text", textFile, "readme.txt", false},
+    {"png sniffed as binary", pngFile, "logo.png", true},
+    {"forced binary by extension", extFile, "notes.custom", true},
+    {"forced binary by glob", globFile, "assets/font.dat", true},
+  }
+
+  for _, c := range cases {
+    got, err := tmpl.isBinary(c.filename, c.oldName)
+    if err != nil {
+      t.Fatalf("%s: isBinary returned error: %s", c.name, err)
+    }
+
+    if got != c.want {
+      t.Errorf("%s: isBinary() = %v, want %v", c.name, got, c.want)
+    }
+  }
+}
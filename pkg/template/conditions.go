@@ -0,0 +1,80 @@
+package template
+
+import (
+  "bytes"
+  "strings"
+  "text/template"
+
+  "github.com/bmatcuk/doublestar"
+)
+
+// shouldRender reports whether the path (relative to the template root)
+// should be rendered, honoring the template's Include, Exclude and
+// Conditions metadata.
+func (t *dirTemplate) shouldRender(oldName string) (bool, error) {
+  md := t.Metadata
+
+  if matchAny(md.Exclude, oldName) {
+    return false, nil
+  }
+
+  if len(md.Include) > 0 && !matchAny(md.Include, oldName) {
+    return false, nil
+  }
+
+  for pattern, expr := range md.Conditions {
+    matched, err := doublestar.Match(pattern, oldName)
+    if err != nil {
+      return false, err
+    } else if !matched {
+      continue
+    }
+
+    truthy, err := t.evalCondition(expr)
+    if err != nil {
+      return false, err
+    } else if !truthy {
+      return false, nil
+    }
+  }
+
+  return true, nil
+}
+
+// matchAny reports whether name matches any of the given doublestar
+// patterns, so "**" can cross directory boundaries (e.g.
+// "**/*.dockerfile" matching at any depth).
+func matchAny(patterns []string, name string) bool {
+  for _, pattern := range patterns {
+    if ok, _ := doublestar.Match(pattern, name); ok {
+      return true
+    }
+  }
+
+  return false
+}
+
+// evalCondition renders expr against FuncMap and reports whether the
+// result is truthy (anything other than empty, "false" or "0"). It
+// honors the template's own Delimiters so a Conditions expression can
+// use the same custom syntax as the rest of the template.
+func (t *dirTemplate) evalCondition(expr string) (bool, error) {
+  left, right := t.Metadata.Delims()
+
+  tmpl, err := template.New("condition").Option(Options...).Delims(left, right).Funcs(FuncMap).Parse(expr)
+  if err != nil {
+    return false, err
+  }
+
+  var buf bytes.Buffer
+  if err := tmpl.Execute(&buf, nil); err != nil {
+    return false, err
+  }
+
+  switch strings.TrimSpace(buf.String()) {
+  case "", "false", "0":
+    return false, nil
+  default:
+    return true, nil
+  }
+}
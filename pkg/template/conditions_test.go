@@ -0,0 +1,69 @@
+package template
+
+import (
+  "io/ioutil"
+  "os"
+  "path/filepath"
+  "testing"
+)
+
+// TestExecuteIncludeNestedPattern guards against a regression where an
+// Include pattern targeting a file nested under a subdirectory (e.g.
+// "k8s/*.yaml") pruned the whole subdirectory before ever reaching the
+// file, because Include misses on directory entries triggered
+// filepath.SkipDir the same way Exclude does.
+func TestExecuteIncludeNestedPattern(t *testing.T) {
+  templatesDir, err := ioutil.TempDir("", "boilr-templates")
+  if err != nil {
+    t.Fatal(err)
+  }
+  defer os.RemoveAll(templatesDir)
+
+  if err := os.MkdirAll(filepath.Join(templatesDir, "k8s"), 0755); err != nil {
+    t.Fatal(err)
+  }
+
+  write := func(rel, contents string) {
+    if err := ioutil.WriteFile(filepath.Join(templatesDir, rel), []byte(contents), 0644); err != nil {
+      t.Fatal(err)
+    }
+  }
+
+  write("k8s/deployment.yaml", "kind: Deployment\n")
+  write("README.md", "hello\n")
+
+  outDir, err := ioutil.TempDir("", "boilr-out")
+  if err != nil {
+    t.Fatal(err)
+  }
+  defer os.RemoveAll(outDir)
+
+  tmpl := &dirTemplate{
+    Path:    templatesDir,
+    FuncMap: FuncMap,
+    Metadata: Metadata{
+      Include: []string{"k8s/*.yaml"},
+    },
+  }
+
+  if err := tmpl.Execute(outDir); err != nil {
+    t.Fatalf("Execute returned error: %s", err)
+  }
+
+  if _, err := os.Stat(filepath.Join(outDir, "k8s", "deployment.yaml")); err != nil {
+    t.Errorf("expected the included nested file to be rendered, got: %s", err)
+  }
+
+  if _, err := os.Stat(filepath.Join(outDir, "README.md")); !os.IsNotExist(err) {
+    t.Errorf("expected the non-included file to be pruned, got err=%v", err)
+  }
+}
+
+// TestMatchAnyDoublestar guards against Include/Exclude/Conditions
+// patterns documented with "**" (crossing directory boundaries) not
+// actually matching more than one directory deep.
+func TestMatchAnyDoublestar(t *testing.T) {
+  if !matchAny([]string{"**/*.dockerfile"}, "a/b/app.dockerfile") {
+    t.Error("expected \"**/*.dockerfile\" to match a file nested two directories deep")
+  }
+}
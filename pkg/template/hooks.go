@@ -0,0 +1,78 @@
+package template
+
+import (
+  "bytes"
+  "fmt"
+  "os"
+  "os/exec"
+  "strings"
+
+  "github.com/tmrts/boilr/pkg/util/tlog"
+)
+
+// shebang marks a rendered file as needing to come out executable, the
+// same way a shell interpreter decides whether to run it.
+var shebang = []byte("#!")
+
+// runHooks runs each command through "sh -c" with the bound template
+// variables exposed as BOILR_<VAR> environment variables, plus any
+// extraEnv, stopping at the first failure. It's a no-op unless the
+// caller opted in via EnableHooks.
+func (t *dirTemplate) runHooks(commands []string, dirPrefix string, extraEnv ...string) error {
+  if !t.HooksAllowed || len(commands) == 0 {
+    return nil
+  }
+
+  env := append(t.hookEnv(), extraEnv...)
+
+  for _, command := range commands {
+    tlog.Debug(fmt.Sprintf("hook: running %q", command))
+
+    cmd := exec.Command("sh", "-c", command)
+    cmd.Dir = dirPrefix
+    cmd.Env = env
+    cmd.Stdout = os.Stdout
+    cmd.Stderr = os.Stderr
+
+    if err := cmd.Run(); err != nil {
+      return fmt.Errorf("hook %q failed: %s", command, err)
+    }
+  }
+
+  return nil
+}
+
+// hookEnv exposes every variable already bound into FuncMap as
+// BOILR_<VAR>=value, alongside the surrounding process environment.
+func (t *dirTemplate) hookEnv() []string {
+  env := os.Environ()
+
+  for name, fn := range t.FuncMap {
+    f, ok := fn.(func() interface{})
+    if !ok {
+      continue
+    }
+
+    env = append(env, fmt.Sprintf("BOILR_%s=%v", strings.ToUpper(name), f()))
+  }
+
+  return env
+}
+
+// rollback removes every path Execute created, in reverse order, so a
+// failed PostGenerate hook doesn't leave a half-generated project
+// behind.
+func (t *dirTemplate) rollback() {
+  for i := len(t.createdPaths) - 1; i >= 0; i-- {
+    if err := os.RemoveAll(t.createdPaths[i]); err != nil {
+      tlog.Debug(fmt.Sprintf("rollback: couldn't remove %q: %s", t.createdPaths[i], err))
+    }
+  }
+}
+
+// isExecutable reports whether a rendered file should be written with
+// the executable bit set: either the template source already had it,
+// or the rendered contents start with a shebang line.
+func isExecutable(sourceMode os.FileMode, contents []byte) bool {
+  return sourceMode&0111 != 0 || bytes.HasPrefix(contents, shebang)
+}
@@ -0,0 +1,87 @@
+package template
+
+import (
+  "io/ioutil"
+  "os"
+  "path/filepath"
+  "testing"
+)
+
+func TestIsExecutable(t *testing.T) {
+  cases := []struct {
+    name       string
+    sourceMode os.FileMode
+    contents   []byte
+    want       bool
+  }{
+    {"executable source mode", 0755, []byte("echo hi\n"), true},
+    {"shebang contents", 0644, []byte("#!/bin/sh\necho hi\n"), true},
+    {"plain text", 0644, []byte("hello\n"), false},
+  }
+
+  for _, c := range cases {
+    if got := isExecutable(c.sourceMode, c.contents); got != c.want {
+      t.Errorf("%s: isExecutable() = %v, want %v", c.name, got, c.want)
+    }
+  }
+}
+
+// TestRollbackScopedToCurrentExecute guards against a failed rollback on
+// a watch-mode rebuild (the same dirTemplate, reused with LiveMode on,
+// across many Execute calls) deleting output an earlier, successful
+// rebuild already wrote and that this rebuild left untouched.
+func TestRollbackScopedToCurrentExecute(t *testing.T) {
+  templatesDir, err := ioutil.TempDir("", "boilr-templates")
+  if err != nil {
+    t.Fatal(err)
+  }
+  defer os.RemoveAll(templatesDir)
+
+  write := func(rel, contents string) {
+    if err := ioutil.WriteFile(filepath.Join(templatesDir, rel), []byte(contents), 0644); err != nil {
+      t.Fatal(err)
+    }
+  }
+
+  write("first.txt", "hello\n")
+
+  outDir, err := ioutil.TempDir("", "boilr-out")
+  if err != nil {
+    t.Fatal(err)
+  }
+  defer os.RemoveAll(outDir)
+
+  tmpl := &dirTemplate{
+    Path:     templatesDir,
+    FuncMap:  FuncMap,
+    LiveMode: true,
+  }
+
+  if err := tmpl.Execute(outDir); err != nil {
+    t.Fatalf("first Execute (rebuild) returned error: %s", err)
+  }
+
+  if _, err := os.Stat(filepath.Join(outDir, "first.txt")); err != nil {
+    t.Fatalf("expected first.txt to exist after the first rebuild: %s", err)
+  }
+
+  // A second rebuild adds a file but fails its PostGenerate hook.
+  // first.txt is unchanged since the first rebuild, so LiveMode's
+  // diffing pass never touches it this time around.
+  write("second.txt", "world\n")
+
+  tmpl.HooksAllowed = true
+  tmpl.Metadata.Hooks.PostGenerate = []string{"exit 1"}
+
+  if err := tmpl.Execute(outDir); err == nil {
+    t.Fatal("expected the second rebuild to fail its PostGenerate hook")
+  }
+
+  if _, err := os.Stat(filepath.Join(outDir, "first.txt")); err != nil {
+    t.Errorf("rollback removed output from an earlier successful rebuild: %s", err)
+  }
+
+  if _, err := os.Stat(filepath.Join(outDir, "second.txt")); !os.IsNotExist(err) {
+    t.Errorf("expected second.txt to be rolled back, got err=%v", err)
+  }
+}
@@ -0,0 +1,79 @@
+package template
+
+// defaultLeftDelim and defaultRightDelim reproduce Go's text/template
+// defaults so templates that don't set Delimiters keep working unchanged.
+const (
+  defaultLeftDelim  = "{{"
+  defaultRightDelim = "}}"
+)
+
+// Metadata holds the template-level configuration read from the
+// template's metadata file (boilr.json).
+type Metadata struct {
+  // Delimiters overrides the default `{{ }}` template action delimiters
+  // with a custom [left, right] pair, e.g. ["<%", "%>"]. This lets
+  // templates for other templated languages (Jinja, Handlebars, LaTeX)
+  // avoid colliding with boilr's own syntax.
+  Delimiters []string `json:"delimiters,omitempty"`
+
+  // Partials lists the names (relative to the partials directory) of
+  // shared templates that should be parsed, in order, before every file
+  // template. When empty, all files under the partials directory are
+  // loaded in the order filepath.Walk visits them.
+  Partials []string `json:"partials,omitempty"`
+
+  // Include, when non-empty, restricts rendering to files (relative to
+  // the template root) matching at least one of these doublestar
+  // patterns ("**" crosses directory boundaries, e.g. "k8s/**/*.yaml").
+  // Exclude drops paths matching any of its patterns, regardless of
+  // Include, and additionally prunes whole matching directories.
+  Include []string `json:"include,omitempty"`
+  Exclude []string `json:"exclude,omitempty"`
+
+  // Conditions maps a doublestar pattern to a template expression
+  // (evaluated against FuncMap) that must render to a truthy value for
+  // matching files to be rendered, e.g. {"**/*.dockerfile": "{{ Docker }}"}.
+  Conditions map[string]string `json:"conditions,omitempty"`
+
+  // Validations maps a prompt variable name to the constraints its
+  // answer must satisfy, whichever AnswerSource supplies it.
+  Validations map[string]Validation `json:"validations,omitempty"`
+
+  // Hooks are shell commands run around generation. They only run when
+  // the caller opted in via dirTemplate.EnableHooks (CLI: --allow-hooks).
+  Hooks Hooks `json:"hooks,omitempty"`
+
+  // BinaryExtensions and BinaryGlobs force paths matching them to be
+  // copied byte-for-byte instead of through the template engine, on top
+  // of whatever http.DetectContentType already sniffs out.
+  BinaryExtensions []string `json:"binaryExtensions,omitempty"`
+  BinaryGlobs      []string `json:"binaryGlobs,omitempty"`
+}
+
+// Hooks are shell commands a template can ask boilr to run around
+// generation. Every command runs through "sh -c" with the rendered
+// context exposed as BOILR_<VAR> environment variables.
+type Hooks struct {
+  // PreGenerate runs, in order, before any file is written.
+  PreGenerate []string `json:"preGenerate,omitempty"`
+
+  // PostGenerate runs, in order, after every file has been written
+  // successfully. If any command fails, the generated output is rolled
+  // back.
+  PostGenerate []string `json:"postGenerate,omitempty"`
+
+  // PostRender maps a doublestar pattern (against the path relative to
+  // the template root) to commands run immediately after a matching
+  // file is rendered, with BOILR_FILE set to its output path.
+  PostRender map[string][]string `json:"postRender,omitempty"`
+}
+
+// Delims returns the left and right template action delimiters declared
+// in the metadata, falling back to Go's defaults when unset.
+func (m Metadata) Delims() (left, right string) {
+  if len(m.Delimiters) != 2 {
+    return defaultLeftDelim, defaultRightDelim
+  }
+
+  return m.Delimiters[0], m.Delimiters[1]
+}
@@ -0,0 +1,80 @@
+package template
+
+import (
+  "io/ioutil"
+  "os"
+  "path/filepath"
+  "text/template"
+
+  "github.com/tmrts/boilr/pkg/util/osutil"
+)
+
+// partialsDirNames are the locations, relative to the template root,
+// that are searched (in order) for shared partials. The first one that
+// exists wins.
+var partialsDirNames = []string{".boilr/partials", "_partials"}
+
+// partials returns the shared base template that every file-content
+// template is derived from, so that `{{ template "name" . }}` resolves
+// across files. The result is cached on the dirTemplate and reused by
+// later Execute calls until partialsTmpl is reset; Watch clears the
+// cache before every rebuild so edited partials are picked up.
+func (t *dirTemplate) partials(left, right string) (*template.Template, error) {
+  if t.partialsTmpl != nil {
+    return t.partialsTmpl, nil
+  }
+
+  base := template.New("partials").Option(Options...).Delims(left, right).Funcs(FuncMap)
+
+  for _, dir := range partialsDirNames {
+    partialsPath := filepath.Join(t.Path, "..", dir)
+
+    exists, err := osutil.FileExists(partialsPath)
+    if err != nil {
+      return nil, err
+    } else if !exists {
+      continue
+    }
+
+    names := t.Metadata.Partials
+    if len(names) == 0 {
+      if err := filepath.Walk(partialsPath, func(path string, info os.FileInfo, err error) error {
+        if err != nil {
+          return err
+        }
+
+        if info.IsDir() {
+          return nil
+        }
+
+        rel, err := filepath.Rel(partialsPath, path)
+        if err != nil {
+          return err
+        }
+
+        names = append(names, rel)
+
+        return nil
+      }); err != nil {
+        return nil, err
+      }
+    }
+
+    for _, name := range names {
+      b, err := ioutil.ReadFile(filepath.Join(partialsPath, name))
+      if err != nil {
+        return nil, err
+      }
+
+      if _, err := base.New(name).Parse(string(b)); err != nil {
+        return nil, err
+      }
+    }
+
+    break
+  }
+
+  t.partialsTmpl = base
+
+  return base, nil
+}
@@ -0,0 +1,84 @@
+package template
+
+import (
+  "io/ioutil"
+  "os"
+  "path/filepath"
+  "testing"
+)
+
+// TestExecuteNestedPartials covers partials referencing each other
+// (header includes a nested "brand" partial) and a file using both,
+// plus Watch's contract of rebuilding the partials cache from scratch.
+func TestExecuteNestedPartials(t *testing.T) {
+  root, err := ioutil.TempDir("", "boilr-template")
+  if err != nil {
+    t.Fatal(err)
+  }
+  defer os.RemoveAll(root)
+
+  templatesDir := filepath.Join(root, "templates")
+  partialsDir := filepath.Join(root, "_partials")
+
+  if err := os.MkdirAll(templatesDir, 0755); err != nil {
+    t.Fatal(err)
+  }
+
+  if err := os.MkdirAll(partialsDir, 0755); err != nil {
+    t.Fatal(err)
+  }
+
+  write := func(dir, rel, contents string) {
+    if err := ioutil.WriteFile(filepath.Join(dir, rel), []byte(contents), 0644); err != nil {
+      t.Fatal(err)
+    }
+  }
+
+  write(partialsDir, "brand", "ACME")
+  write(partialsDir, "header", "# {{ template \"brand\" . }}\n")
+  write(templatesDir, "index.md", "{{ template \"header\" . }}\nbody\n")
+
+  outDir, err := ioutil.TempDir("", "boilr-out")
+  if err != nil {
+    t.Fatal(err)
+  }
+  defer os.RemoveAll(outDir)
+
+  tmpl := &dirTemplate{
+    Path:    templatesDir,
+    FuncMap: FuncMap,
+  }
+
+  if err := tmpl.Execute(outDir); err != nil {
+    t.Fatalf("Execute returned error: %s", err)
+  }
+
+  got, err := ioutil.ReadFile(filepath.Join(outDir, "index.md"))
+  if err != nil {
+    t.Fatalf("couldn't read rendered output: %s", err)
+  }
+
+  want := "# ACME\n\nbody\n"
+  if string(got) != want {
+    t.Errorf("rendered %q, want %q", got, want)
+  }
+
+  // Editing a partial after the cache was built must be picked up once
+  // the cache is invalidated, as Watch does before every rebuild.
+  write(partialsDir, "brand", "WIDGETCO")
+  tmpl.partialsTmpl = nil
+
+  if err := tmpl.Execute(outDir); err != nil {
+    t.Fatalf("second Execute returned error: %s", err)
+  }
+
+  got, err = ioutil.ReadFile(filepath.Join(outDir, "index.md"))
+  if err != nil {
+    t.Fatalf("couldn't read re-rendered output: %s", err)
+  }
+
+  want = "# WIDGETCO\n\nbody\n"
+  if string(got) != want {
+    t.Errorf("rendered %q after partial edit, want %q", got, want)
+  }
+}
@@ -1,6 +1,9 @@
 package template
 
 import (
+  "bytes"
+  "crypto/sha256"
+  "github.com/bmatcuk/doublestar"
   "encoding/json"
   "fmt"
   "io/ioutil"
@@ -22,9 +25,17 @@ type Interface interface {
   // Executes the template on the given target directory path.
   Execute(string) error
 
+  // Watch observes the template's source tree and re-executes the
+  // template into the target directory whenever it changes.
+  Watch(string) error
+
   // If used, the template will execute using default values.
   UseDefaultValues()
 
+  // EnableHooks opts into running the template's PreGenerate,
+  // PostGenerate and PostRender hooks.
+  EnableHooks()
+
   // Returns the metadata of the template.
   Info() Metadata
 }
@@ -112,18 +123,59 @@ type dirTemplate struct {
   alignment         string
   ShouldUseDefaults bool
   JsonFile          string
+
+  // LiveMode is set by Watch. While true, Execute suppresses prompts,
+  // reuses the context gathered on the first run, and skips rewriting
+  // outputs whose rendered contents haven't changed.
+  LiveMode     bool
+  outputHashes map[string][sha256.Size]byte
+
+  // partialsTmpl caches the shared base template built from the
+  // template's partials directory (see partials.go).
+  partialsTmpl *template.Template
+
+  // AnswerSources are consulted by BindPrompts before it falls back to
+  // interactive prompting (see answers.go).
+  AnswerSources []AnswerSource
+
+  // HooksAllowed opts into running the template-supplied Hooks (CLI:
+  // --allow-hooks). Templates are untrusted input, so hooks are
+  // disabled by default.
+  HooksAllowed bool
+  createdPaths []string
 }
 
 func (t *dirTemplate) UseDefaultValues() {
   t.ShouldUseDefaults = true
 }
 
-func (t *dirTemplate) BindPrompts() {
+func (t *dirTemplate) EnableHooks() {
+  t.HooksAllowed = true
+}
+
+// BindPrompts binds each template variable into FuncMap, preferring an
+// answer from AnswerSources when one is available and falling back to
+// ShouldUseDefaults / interactive prompting otherwise. It returns an
+// error listing every variable whose answer failed validation.
+func (t *dirTemplate) BindPrompts() error {
+  var invalid []string
+
   for s, v := range t.Context {
     if m, ok := v.(map[string]interface{}); ok {
       advancedMode := prompt.New(s, false)
 
       for k, v2 := range m {
+        if val, ok := t.lookupAnswer(k); ok {
+          validated, err := validateAnswer(t.Metadata, k, val)
+          if err != nil {
+            invalid = append(invalid, err.Error())
+            continue
+          }
+
+          t.FuncMap[k] = func() interface{} { return validated }
+          continue
+        }
+
         if t.ShouldUseDefaults {
           t.FuncMap[k] = func() interface{} {
             switch v2 := v2.(type) {
@@ -151,6 +203,17 @@ func (t *dirTemplate) BindPrompts() {
       continue
     }
 
+    if val, ok := t.lookupAnswer(s); ok {
+      validated, err := validateAnswer(t.Metadata, s, val)
+      if err != nil {
+        invalid = append(invalid, err.Error())
+        continue
+      }
+
+      t.FuncMap[s] = func() interface{} { return validated }
+      continue
+    }
+
     if t.ShouldUseDefaults {
       t.FuncMap[s] = func(s2 string, v2 interface{}) func() interface{} {
 	return func() interface{} {
@@ -168,11 +231,36 @@ func (t *dirTemplate) BindPrompts() {
       t.FuncMap[s] = prompt.New(s, v)
     }
   }
+
+  if len(invalid) > 0 {
+    return fmt.Errorf("invalid or missing answers: %s", strings.Join(invalid, "; "))
+  }
+
+  return nil
 }
 
 // Execute fills the template with the project metadata.
 func (t *dirTemplate) Execute(dirPrefix string) error {
-  t.BindPrompts()
+  // In live mode, only the first render prompts; rebuilds reuse the
+  // context already bound into FuncMap.
+  if !t.LiveMode || t.outputHashes == nil {
+    if err := t.BindPrompts(); err != nil {
+      return err
+    }
+  }
+
+  if t.outputHashes == nil {
+    t.outputHashes = map[string][sha256.Size]byte{}
+  }
+
+  // createdPaths only tracks this call's writes, so a failed rollback
+  // never touches output a previous, successful Execute (e.g. an
+  // earlier watch-mode rebuild) already wrote.
+  t.createdPaths = nil
+
+  if err := t.runHooks(t.Metadata.Hooks.PreGenerate, dirPrefix); err != nil {
+    return err
+  }
 
   isOnlyWhitespace := func(buf []byte) bool {
     wsre := regexp.MustCompile(`\S`)
@@ -182,7 +270,7 @@ func (t *dirTemplate) Execute(dirPrefix string) error {
 
   // TODO create io.ReadWriter from string
   // TODO refactor name manipulation
-  return filepath.Walk(t.Path, func(filename string, info os.FileInfo, err error) error {
+  walkErr := filepath.Walk(t.Path, func(filename string, info os.FileInfo, err error) error {
     if err != nil {
       return err
     }
@@ -194,12 +282,34 @@ func (t *dirTemplate) Execute(dirPrefix string) error {
       return err
     }
 
+    if oldName != "." {
+      if info.IsDir() {
+        // Include/Conditions are evaluated per file, not per directory:
+        // an Include pattern targeting a nested file (e.g. "k8s/*.yaml")
+        // never matches the "k8s" directory entry itself, so only
+        // Exclude - which is meant to prune whole subtrees - skips here.
+        if matchAny(t.Metadata.Exclude, oldName) {
+          return filepath.SkipDir
+        }
+      } else {
+        render, err := t.shouldRender(oldName)
+        if err != nil {
+          return err
+        } else if !render {
+          return nil
+        }
+      }
+    }
+
     buf := stringutil.NewString("")
 
+    left, right := t.Metadata.Delims()
+
     // TODO translate errors into meaningful ones
     fnameTmpl := template.Must(template.
       New("file name template").
       Option(Options...).
+      Delims(left, right).
       Funcs(FuncMap).
       Parse(oldName))
 
@@ -216,9 +326,35 @@ func (t *dirTemplate) Execute(dirPrefix string) error {
         if !os.IsExist(err) {
           return err
         }
+      } else {
+        t.createdPaths = append(t.createdPaths, target)
+      }
+    } else if binary, err := t.isBinary(filename, oldName); err != nil {
+      return err
+    } else if binary {
+      if t.LiveMode {
+        contents, err := ioutil.ReadFile(filename)
+        if err != nil {
+          return err
+        }
+
+        hash := sha256.Sum256(contents)
+        if prev, ok := t.outputHashes[target]; ok && prev == hash {
+          return nil
+        }
+        t.outputHashes[target] = hash
+      }
+
+      if err := copyBinary(filename, target); err != nil {
+        return err
+      }
+
+      t.createdPaths = append(t.createdPaths, target)
+
+      if !t.ShouldUseDefaults {
+        tlog.Success(fmt.Sprintf("Created %s", newName))
       }
-    } else if strings.HasSuffix(oldName, ".png") {
-      osutil.CopyRecursively(oldName, target)
+
       return nil
     } else {
       fi, err := os.Lstat(filename)
@@ -226,17 +362,59 @@ func (t *dirTemplate) Execute(dirPrefix string) error {
         return err
       }
 
+      partials, err := t.partials(left, right)
+      if err != nil {
+        return err
+      }
+
+      base, err := partials.Clone()
+      if err != nil {
+        return err
+      }
+
+      contents, err := ioutil.ReadFile(filename)
+      if err != nil {
+        return err
+      }
+
+      fileTemplateName := filepath.Base(filename)
+
+      contentsTmpl, err := base.New(fileTemplateName).Parse(string(contents))
+      if err != nil {
+        return err
+      }
+
+      rendered := new(bytes.Buffer)
+      if err := contentsTmpl.ExecuteTemplate(rendered, fileTemplateName, nil); err != nil {
+        return err
+      }
+
+      hash := sha256.Sum256(rendered.Bytes())
+      if t.LiveMode {
+        if prev, ok := t.outputHashes[target]; ok && prev == hash {
+          return nil
+        }
+      }
+      t.outputHashes[target] = hash
+
       // Delete target file if it exists
       if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
         return err
       }
 
-      f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY, fi.Mode())
+      mode := fi.Mode()
+      if isExecutable(mode, rendered.Bytes()) {
+        mode = 0755
+      }
+
+      f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY, mode)
       if err != nil {
         return err
       }
       defer f.Close()
 
+      t.createdPaths = append(t.createdPaths, target)
+
       defer func(fname string) {
         contents, err := ioutil.ReadFile(fname)
         if err != nil {
@@ -250,23 +428,40 @@ func (t *dirTemplate) Execute(dirPrefix string) error {
         }
       }(f.Name())
 
-      contentsTmpl := template.Must(template.
-        New("file contents template").
-        Option(Options...).
-        Funcs(FuncMap).
-        ParseFiles(filename))
-
-      fileTemplateName := filepath.Base(filename)
-
-      if err := contentsTmpl.ExecuteTemplate(f, fileTemplateName, nil); err != nil {
+      if _, err := f.Write(rendered.Bytes()); err != nil {
         return err
       }
 
       if !t.ShouldUseDefaults {
         tlog.Success(fmt.Sprintf("Created %s", newName))
       }
+
+      for pattern, commands := range t.Metadata.Hooks.PostRender {
+        matched, err := doublestar.Match(pattern, oldName)
+        if err != nil {
+          return err
+        } else if !matched {
+          continue
+        }
+
+        if err := t.runHooks(commands, dirPrefix, "BOILR_FILE="+target); err != nil {
+          return err
+        }
+      }
     }
 
     return nil
   })
+
+  if walkErr != nil {
+    t.rollback()
+    return walkErr
+  }
+
+  if err := t.runHooks(t.Metadata.Hooks.PostGenerate, dirPrefix); err != nil {
+    t.rollback()
+    return err
+  }
+
+  return nil
 }
@@ -0,0 +1,110 @@
+package template
+
+import (
+  "fmt"
+  "regexp"
+  "strconv"
+)
+
+// Validation describes the constraints a prompt answer must satisfy,
+// enforced before the value is assigned into FuncMap.
+type Validation struct {
+  // Type is one of "string" (default), "number" or "bool".
+  Type string `json:"type,omitempty"`
+
+  // Regex, for string answers, must match the whole answer.
+  Regex string `json:"regex,omitempty"`
+
+  // Min and Max bound number answers.
+  Min *float64 `json:"min,omitempty"`
+  Max *float64 `json:"max,omitempty"`
+
+  // Enum, for string answers, lists the only accepted values.
+  Enum []string `json:"enum,omitempty"`
+}
+
+// validateAnswer checks val against the Validation rule registered for
+// variable, if any, returning the value coerced to the rule's type.
+func validateAnswer(md Metadata, variable string, val interface{}) (interface{}, error) {
+  rule, ok := md.Validations[variable]
+  if !ok {
+    return val, nil
+  }
+
+  switch rule.Type {
+  case "", "string":
+    s, ok := val.(string)
+    if !ok {
+      return nil, fmt.Errorf("%s: expected a string", variable)
+    }
+
+    if rule.Regex != "" {
+      matched, err := regexp.MatchString("^(?:"+rule.Regex+")$", s)
+      if err != nil {
+        return nil, err
+      } else if !matched {
+        return nil, fmt.Errorf("%s: %q does not match %q", variable, s, rule.Regex)
+      }
+    }
+
+    if len(rule.Enum) > 0 && !stringInSlice(s, rule.Enum) {
+      return nil, fmt.Errorf("%s: %q must be one of %v", variable, s, rule.Enum)
+    }
+
+    return s, nil
+  case "number":
+    n, err := toFloat64(val)
+    if err != nil {
+      return nil, fmt.Errorf("%s: %s", variable, err)
+    }
+
+    if rule.Min != nil && n < *rule.Min {
+      return nil, fmt.Errorf("%s: %v is less than the minimum %v", variable, n, *rule.Min)
+    }
+
+    if rule.Max != nil && n > *rule.Max {
+      return nil, fmt.Errorf("%s: %v is greater than the maximum %v", variable, n, *rule.Max)
+    }
+
+    return n, nil
+  case "bool":
+    switch v := val.(type) {
+    case bool:
+      return v, nil
+    case string:
+      b, err := strconv.ParseBool(v)
+      if err != nil {
+        return nil, fmt.Errorf("%s: expected a bool", variable)
+      }
+
+      return b, nil
+    default:
+      return nil, fmt.Errorf("%s: expected a bool", variable)
+    }
+  default:
+    return nil, fmt.Errorf("%s: unknown validation type %q", variable, rule.Type)
+  }
+}
+
+func stringInSlice(s string, list []string) bool {
+  for _, v := range list {
+    if v == s {
+      return true
+    }
+  }
+
+  return false
+}
+
+func toFloat64(val interface{}) (float64, error) {
+  switch v := val.(type) {
+  case float64:
+    return v, nil
+  case int:
+    return float64(v), nil
+  case string:
+    return strconv.ParseFloat(v, 64)
+  default:
+    return 0, fmt.Errorf("expected a number")
+  }
+}
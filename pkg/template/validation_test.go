@@ -0,0 +1,27 @@
+package template
+
+import "testing"
+
+// TestValidateAnswerBoolCoercion guards against EnvAnswerSource answers
+// (always strings, e.g. BOILR_VAR_ENABLE_CI=true) being rejected by a
+// "bool"-typed Validation just because they aren't a Go bool already.
+func TestValidateAnswerBoolCoercion(t *testing.T) {
+  md := Metadata{
+    Validations: map[string]Validation{
+      "EnableCI": {Type: "bool"},
+    },
+  }
+
+  got, err := validateAnswer(md, "EnableCI", "true")
+  if err != nil {
+    t.Fatalf("validateAnswer returned error: %s", err)
+  }
+
+  if got != true {
+    t.Errorf("validateAnswer(%q) = %v, want true", "true", got)
+  }
+
+  if _, err := validateAnswer(md, "EnableCI", "not-a-bool"); err == nil {
+    t.Error("expected an error for a non-boolean string")
+  }
+}
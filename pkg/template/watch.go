@@ -0,0 +1,138 @@
+package template
+
+import (
+  "fmt"
+  "os"
+  "path/filepath"
+  "sync"
+  "time"
+
+  "github.com/fsnotify/fsnotify"
+
+  "github.com/tmrts/boilr/pkg/boilr"
+  "github.com/tmrts/boilr/pkg/util/tlog"
+)
+
+// watchDebounce coalesces bursts of filesystem events (an editor writing
+// a file in several steps, a save-all across many files) into a single
+// rebuild.
+const watchDebounce = 200 * time.Millisecond
+
+// Watch observes the template's `templates/` directory, its partials
+// directory and its project.json for changes and re-executes Execute
+// into dirPrefix on every change, debounced and with unchanged outputs
+// left untouched. The context gathered on the first render is reused on
+// every rebuild, so template authors aren't re-prompted after each edit.
+func (t *dirTemplate) Watch(dirPrefix string) error {
+  t.LiveMode = true
+
+  watcher, err := fsnotify.NewWatcher()
+  if err != nil {
+    return err
+  }
+  defer watcher.Close()
+
+  watchDir := func(root string) error {
+    return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+      if err != nil {
+        if os.IsNotExist(err) {
+          return nil
+        }
+
+        return err
+      }
+
+      if info.IsDir() {
+        return watcher.Add(path)
+      }
+
+      return nil
+    })
+  }
+
+  if err := watchDir(t.Path); err != nil {
+    return err
+  }
+
+  for _, dir := range partialsDirNames {
+    if err := watchDir(filepath.Join(t.Path, "..", dir)); err != nil {
+      tlog.Debug(fmt.Sprintf("watch: not watching %s: %s", dir, err))
+    }
+  }
+
+  if err := watcher.Add(filepath.Join(t.Path, "..", boilr.ContextFileName)); err != nil {
+    tlog.Debug(fmt.Sprintf("watch: not watching %s: %s", boilr.ContextFileName, err))
+  }
+
+  if err := t.Execute(dirPrefix); err != nil {
+    return err
+  }
+
+  // running/pending serialize rebuilds: a debounce firing while a
+  // rebuild is already executing queues one more rebuild instead of
+  // running Execute concurrently, which would race on t.outputHashes
+  // and t.createdPaths (unsynchronized concurrent map writes crash the
+  // process).
+  var (
+    mu      sync.Mutex
+    running bool
+    pending bool
+  )
+
+  var rebuild func()
+  rebuild = func() {
+    mu.Lock()
+    if running {
+      pending = true
+      mu.Unlock()
+
+      return
+    }
+    running = true
+    mu.Unlock()
+
+    tlog.Debug("watch: rebuilding")
+
+    // Partials may have changed since the last render; rebuild the
+    // cached base template from scratch instead of reusing it for the
+    // dirTemplate's whole lifetime.
+    t.partialsTmpl = nil
+
+    if err := t.Execute(dirPrefix); err != nil {
+      tlog.Error(fmt.Sprintf("watch: rebuild failed: %s", err))
+    }
+
+    mu.Lock()
+    running = false
+    again := pending
+    pending = false
+    mu.Unlock()
+
+    if again {
+      rebuild()
+    }
+  }
+
+  var timer *time.Timer
+  for {
+    select {
+    case event, ok := <-watcher.Events:
+      if !ok {
+        return nil
+      }
+
+      tlog.Debug(fmt.Sprintf("watch: %s changed, scheduling rebuild", event.Name))
+
+      if timer != nil {
+        timer.Stop()
+      }
+      timer = time.AfterFunc(watchDebounce, rebuild)
+    case err, ok := <-watcher.Errors:
+      if !ok {
+        return nil
+      }
+
+      tlog.Error(fmt.Sprintf("watch: %s", err))
+    }
+  }
+}